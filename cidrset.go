@@ -0,0 +1,192 @@
+package range2cidr
+
+import (
+	"math/big"
+	"net"
+	"sort"
+)
+
+// cidrInterval 表示一个CIDR块在big.Int坐标系下的闭区间 [start, end]
+type cidrInterval struct {
+	start, end *big.Int
+	byteLen    int
+}
+
+// cidrToInterval 将CIDR转换为其覆盖的闭区间
+func cidrToInterval(n *net.IPNet) (*cidrInterval, bool) {
+	if n == nil {
+		return nil, false
+	}
+	ip := standardizeIP(n.IP)
+	if ip == nil {
+		return nil, false
+	}
+	byteLen := len(ip)
+	ones, bits := n.Mask.Size()
+	if bits != byteLen*8 {
+		return nil, false
+	}
+
+	start := new(big.Int).SetBytes(ip)
+	end := new(big.Int).Set(start)
+	if hostLen := bits - ones; hostLen > 0 {
+		blockSize := new(big.Int).Lsh(big.NewInt(1), uint(hostLen))
+		blockSize.Sub(blockSize, big.NewInt(1))
+		end.Add(end, blockSize)
+	}
+	return &cidrInterval{start: start, end: end, byteLen: byteLen}, true
+}
+
+// bigIntToIP 将big.Int还原为指定字节长度的net.IP
+func bigIntToIP(n *big.Int, byteLen int) net.IP {
+	b := make([]byte, byteLen)
+	n.FillBytes(b)
+	return net.IP(b)
+}
+
+// toSortedIntervals 将CIDR列表转换为按起始地址排序的区间列表，非法条目会被忽略
+func toSortedIntervals(nets []*net.IPNet) []*cidrInterval {
+	intervals := make([]*cidrInterval, 0, len(nets))
+	for _, n := range nets {
+		if iv, ok := cidrToInterval(n); ok {
+			intervals = append(intervals, iv)
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		if intervals[i].byteLen != intervals[j].byteLen {
+			return intervals[i].byteLen < intervals[j].byteLen
+		}
+		return intervals[i].start.Cmp(intervals[j].start) < 0
+	})
+	return intervals
+}
+
+// mergeIntervals 合并相邻或重叠的区间（要求输入已按起始地址排序）
+func mergeIntervals(intervals []*cidrInterval) []*cidrInterval {
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	merged := []*cidrInterval{intervals[0]}
+	for _, cur := range intervals[1:] {
+		last := merged[len(merged)-1]
+		if cur.byteLen == last.byteLen {
+			// end+1 >= next.start 说明两个区间相邻或重叠
+			touching := new(big.Int).Add(last.end, big.NewInt(1))
+			if touching.Cmp(cur.start) >= 0 {
+				if cur.end.Cmp(last.end) > 0 {
+					last.end = cur.end
+				}
+				continue
+			}
+		}
+		merged = append(merged, cur)
+	}
+	return merged
+}
+
+// intervalsToCIDRs 将区间列表重新拆分为最小CIDR覆盖集
+func intervalsToCIDRs(intervals []*cidrInterval) []*net.IPNet {
+	var result []*net.IPNet
+	for _, iv := range intervals {
+		startIP := bigIntToIP(iv.start, iv.byteLen)
+		endIP := bigIntToIP(iv.end, iv.byteLen)
+		cidrs, err := Range2CIDRs(startIP, endIP)
+		if err != nil {
+			continue
+		}
+		result = append(result, cidrs...)
+	}
+	return result
+}
+
+// MergeCIDRs 合并相邻或重叠的CIDR块，返回最小覆盖集
+func MergeCIDRs(nets []*net.IPNet) []*net.IPNet {
+	intervals := mergeIntervals(toSortedIntervals(nets))
+	return intervalsToCIDRs(intervals)
+}
+
+// SubtractCIDRs 计算 a \ b，返回最小CIDR列表
+func SubtractCIDRs(a, b []*net.IPNet) []*net.IPNet {
+	aIntervals := mergeIntervals(toSortedIntervals(a))
+	bIntervals := mergeIntervals(toSortedIntervals(b))
+
+	var remaining []*cidrInterval
+	j := 0
+	for _, av := range aIntervals {
+		cur := new(big.Int).Set(av.start)
+		for j < len(bIntervals) && (bIntervals[j].byteLen < av.byteLen ||
+			(bIntervals[j].byteLen == av.byteLen && bIntervals[j].end.Cmp(cur) < 0)) {
+			j++
+		}
+		k := j
+		for k < len(bIntervals) && bIntervals[k].byteLen == av.byteLen && bIntervals[k].start.Cmp(av.end) <= 0 {
+			bv := bIntervals[k]
+			if bv.start.Cmp(cur) > 0 {
+				remaining = append(remaining, &cidrInterval{
+					start:   new(big.Int).Set(cur),
+					end:     new(big.Int).Sub(bv.start, big.NewInt(1)),
+					byteLen: av.byteLen,
+				})
+			}
+			if next := new(big.Int).Add(bv.end, big.NewInt(1)); next.Cmp(cur) > 0 {
+				cur = next
+			}
+			if cur.Cmp(av.end) > 0 {
+				break
+			}
+			k++
+		}
+		if cur.Cmp(av.end) <= 0 {
+			remaining = append(remaining, &cidrInterval{
+				start:   cur,
+				end:     av.end,
+				byteLen: av.byteLen,
+			})
+		}
+	}
+	return intervalsToCIDRs(remaining)
+}
+
+// IntersectCIDRs 计算 a 与 b 的交集，返回最小CIDR列表
+func IntersectCIDRs(a, b []*net.IPNet) []*net.IPNet {
+	aIntervals := mergeIntervals(toSortedIntervals(a))
+	bIntervals := mergeIntervals(toSortedIntervals(b))
+
+	var inter []*cidrInterval
+	i, j := 0, 0
+	for i < len(aIntervals) && j < len(bIntervals) {
+		av, bv := aIntervals[i], bIntervals[j]
+		if av.byteLen != bv.byteLen {
+			if av.byteLen < bv.byteLen {
+				i++
+			} else {
+				j++
+			}
+			continue
+		}
+
+		start := av.start
+		if bv.start.Cmp(start) > 0 {
+			start = bv.start
+		}
+		end := av.end
+		if bv.end.Cmp(end) < 0 {
+			end = bv.end
+		}
+		if start.Cmp(end) <= 0 {
+			inter = append(inter, &cidrInterval{
+				start:   new(big.Int).Set(start),
+				end:     new(big.Int).Set(end),
+				byteLen: av.byteLen,
+			})
+		}
+
+		if av.end.Cmp(bv.end) <= 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return intervalsToCIDRs(inter)
+}
@@ -0,0 +1,67 @@
+package range2cidr
+
+import (
+	"net"
+	"testing"
+)
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatalf("解析CIDR失败 %s: %v", s, err)
+	}
+	return n
+}
+
+func cidrStrings(nets []*net.IPNet) []string {
+	out := make([]string, len(nets))
+	for i, n := range nets {
+		out[i] = n.String()
+	}
+	return out
+}
+
+func TestMergeCIDRs(t *testing.T) {
+	in := []*net.IPNet{
+		mustParseCIDR(t, "192.168.0.128/25"),
+		mustParseCIDR(t, "192.168.0.0/25"),
+		mustParseCIDR(t, "10.0.0.0/24"),
+	}
+	got := cidrStrings(MergeCIDRs(in))
+	want := []string{"10.0.0.0/24", "192.168.0.0/24"}
+	if len(got) != len(want) {
+		t.Fatalf("MergeCIDRs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("MergeCIDRs() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSubtractCIDRs(t *testing.T) {
+	a := []*net.IPNet{mustParseCIDR(t, "192.168.0.0/24")}
+	b := []*net.IPNet{mustParseCIDR(t, "192.168.0.64/27")}
+
+	got := cidrStrings(SubtractCIDRs(a, b))
+	want := []string{"192.168.0.0/26", "192.168.0.96/27", "192.168.0.128/25"}
+	if len(got) != len(want) {
+		t.Fatalf("SubtractCIDRs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SubtractCIDRs() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestIntersectCIDRs(t *testing.T) {
+	a := []*net.IPNet{mustParseCIDR(t, "192.168.0.0/24")}
+	b := []*net.IPNet{mustParseCIDR(t, "192.168.0.128/25")}
+
+	got := cidrStrings(IntersectCIDRs(a, b))
+	want := []string{"192.168.0.128/25"}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("IntersectCIDRs() = %v, want %v", got, want)
+	}
+}
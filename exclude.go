@@ -0,0 +1,63 @@
+package range2cidr
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// Range2CIDRsExcluding 返回 [start, end] 范围内去掉excludes覆盖部分后的最小CIDR覆盖集，
+// 适用于“扫描/放行某个大范围，但排除其中若干子块”的场景
+func Range2CIDRsExcluding(start, end net.IP, excludes []*net.IPNet) ([]*net.IPNet, error) {
+	stdStart := standardizeIP(start)
+	stdEnd := standardizeIP(end)
+	if stdStart == nil || stdEnd == nil {
+		return nil, fmt.Errorf("无效的IP地址")
+	}
+	if len(stdStart) != len(stdEnd) {
+		return nil, fmt.Errorf("IP版本不匹配")
+	}
+	byteLen := len(stdStart)
+
+	startInt := new(big.Int).SetBytes(stdStart)
+	endInt := new(big.Int).SetBytes(stdEnd)
+	if startInt.Cmp(endInt) > 0 {
+		return nil, fmt.Errorf("起始IP大于结束IP")
+	}
+
+	excludeIntervals := mergeIntervals(toSortedIntervals(excludes))
+
+	var cidrs []*net.IPNet
+	cur := startInt
+	for _, iv := range excludeIntervals {
+		if iv.byteLen != byteLen || iv.end.Cmp(cur) < 0 || iv.start.Cmp(endInt) > 0 {
+			continue
+		}
+
+		// 缺口在当前exclude块之前，走核心贪心拆分器转换为CIDR
+		if iv.start.Cmp(cur) > 0 {
+			gapEnd := new(big.Int).Sub(iv.start, big.NewInt(1))
+			gap, err := Range2CIDRs(bigIntToIP(cur, byteLen), bigIntToIP(gapEnd, byteLen))
+			if err != nil {
+				return nil, err
+			}
+			cidrs = append(cidrs, gap...)
+		}
+
+		if next := new(big.Int).Add(iv.end, big.NewInt(1)); next.Cmp(cur) > 0 {
+			cur = next
+		}
+		if cur.Cmp(endInt) > 0 {
+			break
+		}
+	}
+
+	if cur.Cmp(endInt) <= 0 {
+		gap, err := Range2CIDRs(bigIntToIP(cur, byteLen), bigIntToIP(endInt, byteLen))
+		if err != nil {
+			return nil, err
+		}
+		cidrs = append(cidrs, gap...)
+	}
+	return cidrs, nil
+}
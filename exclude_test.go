@@ -0,0 +1,55 @@
+package range2cidr
+
+import (
+	"net"
+	"testing"
+)
+
+func TestRange2CIDRsExcluding(t *testing.T) {
+	start := net.ParseIP("192.168.0.0")
+	end := net.ParseIP("192.168.0.255")
+	excludes := []*net.IPNet{mustParseCIDR(t, "192.168.0.64/27")}
+
+	got, err := Range2CIDRsExcluding(start, end, excludes)
+	if err != nil {
+		t.Fatalf("Range2CIDRsExcluding() error = %v", err)
+	}
+
+	want := []string{"192.168.0.0/26", "192.168.0.96/27", "192.168.0.128/25"}
+	gotStr := cidrStrings(got)
+	if len(gotStr) != len(want) {
+		t.Fatalf("Range2CIDRsExcluding() = %v, want %v", gotStr, want)
+	}
+	for i := range want {
+		if gotStr[i] != want[i] {
+			t.Fatalf("Range2CIDRsExcluding() = %v, want %v", gotStr, want)
+		}
+	}
+}
+
+func TestRange2CIDRsExcludingNone(t *testing.T) {
+	start := net.ParseIP("10.0.0.0")
+	end := net.ParseIP("10.0.0.255")
+
+	got, err := Range2CIDRsExcluding(start, end, nil)
+	if err != nil {
+		t.Fatalf("Range2CIDRsExcluding() error = %v", err)
+	}
+	if len(got) != 1 || got[0].String() != "10.0.0.0/24" {
+		t.Fatalf("Range2CIDRsExcluding() = %v, want [10.0.0.0/24]", cidrStrings(got))
+	}
+}
+
+func TestRange2CIDRsExcludingEverything(t *testing.T) {
+	start := net.ParseIP("10.0.0.0")
+	end := net.ParseIP("10.0.0.255")
+	excludes := []*net.IPNet{mustParseCIDR(t, "10.0.0.0/24")}
+
+	got, err := Range2CIDRsExcluding(start, end, excludes)
+	if err != nil {
+		t.Fatalf("Range2CIDRsExcluding() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("Range2CIDRsExcluding() = %v, want empty", cidrStrings(got))
+	}
+}
@@ -0,0 +1,153 @@
+// Package iptable 基于range2cidr加载制表符分隔的范围表，
+// 支持对IP地址做O(log N)查找，返回其所属的ASN与组织名。
+// 第三列必须是十进制ASN；国家代码等非数字取值会使Load返回错误。
+package iptable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MZCL2012/range2cidr"
+)
+
+// Entry 表示范围表中的一条记录
+type Entry struct {
+	Start, End net.IP
+	ASN        int
+	Org        string
+}
+
+// entryInterval 是Entry在big.Int坐标系下的闭区间，用于排序与二分查找
+type entryInterval struct {
+	start, end *big.Int
+	byteLen    int
+	entry      *Entry
+}
+
+// Table 是按起始地址排序的范围表
+type Table struct {
+	intervals []entryInterval
+}
+
+// standardizeIP 标准化IP地址为4或16字节
+func standardizeIP(ip net.IP) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		return v4
+	}
+	return ip.To16()
+}
+
+// Load 从io.Reader中加载制表符分隔的范围表，每行格式为 startIP\tendIP\tASN\tOrg
+func Load(r io.Reader) (*Table, error) {
+	var intervals []entryInterval
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("字段数量不足: %s", line)
+		}
+
+		startIP := net.ParseIP(strings.TrimSpace(fields[0]))
+		endIP := net.ParseIP(strings.TrimSpace(fields[1]))
+		if startIP == nil || endIP == nil {
+			return nil, fmt.Errorf("无效的IP地址: %s", line)
+		}
+		asn, err := strconv.Atoi(strings.TrimSpace(fields[2]))
+		if err != nil {
+			return nil, fmt.Errorf("无效的ASN: %s", line)
+		}
+		org := strings.TrimSpace(fields[3])
+
+		stdStart := standardizeIP(startIP)
+		stdEnd := standardizeIP(endIP)
+		if len(stdStart) != len(stdEnd) {
+			return nil, fmt.Errorf("IP版本不匹配: %s", line)
+		}
+
+		intervals = append(intervals, entryInterval{
+			start:   new(big.Int).SetBytes(stdStart),
+			end:     new(big.Int).SetBytes(stdEnd),
+			byteLen: len(stdStart),
+			entry: &Entry{
+				Start: startIP,
+				End:   endIP,
+				ASN:   asn,
+				Org:   org,
+			},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		if intervals[i].byteLen != intervals[j].byteLen {
+			return intervals[i].byteLen < intervals[j].byteLen
+		}
+		return intervals[i].start.Cmp(intervals[j].start) < 0
+	})
+	return &Table{intervals: intervals}, nil
+}
+
+// Lookup 在O(log N)内查找ip所属的记录
+func (t *Table) Lookup(ip net.IP) (*Entry, bool) {
+	stdIP := standardizeIP(ip)
+	if stdIP == nil {
+		return nil, false
+	}
+	target := new(big.Int).SetBytes(stdIP)
+	byteLen := len(stdIP)
+
+	i := sort.Search(len(t.intervals), func(i int) bool {
+		iv := t.intervals[i]
+		if iv.byteLen != byteLen {
+			return iv.byteLen > byteLen
+		}
+		return iv.start.Cmp(target) > 0
+	})
+	if i == 0 {
+		return nil, false
+	}
+
+	iv := t.intervals[i-1]
+	if iv.byteLen != byteLen || iv.end.Cmp(target) < 0 {
+		return nil, false
+	}
+	return iv.entry, true
+}
+
+// ASNRanges 返回属于asn的所有 [Start, End] 区间
+func (t *Table) ASNRanges(asn int) []range2cidr.IPRange {
+	var ranges []range2cidr.IPRange
+	for _, iv := range t.intervals {
+		if iv.entry.ASN == asn {
+			ranges = append(ranges, range2cidr.IPRange{Start: iv.entry.Start, End: iv.entry.End})
+		}
+	}
+	return ranges
+}
+
+// ASNCIDRs 将asn对应的范围转换为可直接扫描的CIDR列表
+func (t *Table) ASNCIDRs(asn int) []*net.IPNet {
+	var cidrs []*net.IPNet
+	for _, r := range t.ASNRanges(asn) {
+		c, err := range2cidr.Range2CIDRs(r.Start, r.End)
+		if err != nil {
+			continue
+		}
+		cidrs = append(cidrs, c...)
+	}
+	return range2cidr.MergeCIDRs(cidrs)
+}
@@ -0,0 +1,42 @@
+package iptable
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+const sampleTable = `1.1.1.0\t1.1.1.255\t13335\tCloudflare
+8.8.8.0\t8.8.8.255\t15169\tGoogle
+`
+
+func TestLookup(t *testing.T) {
+	table, err := Load(strings.NewReader(strings.ReplaceAll(sampleTable, `\t`, "\t")))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	entry, ok := table.Lookup(net.ParseIP("1.1.1.1"))
+	if !ok {
+		t.Fatalf("Lookup() found = false, want true")
+	}
+	if entry.ASN != 13335 || entry.Org != "Cloudflare" {
+		t.Fatalf("Lookup() = %+v, want ASN 13335 Cloudflare", entry)
+	}
+
+	if _, ok := table.Lookup(net.ParseIP("9.9.9.9")); ok {
+		t.Fatalf("Lookup() found = true for IP not in table, want false")
+	}
+}
+
+func TestASNCIDRs(t *testing.T) {
+	table, err := Load(strings.NewReader(strings.ReplaceAll(sampleTable, `\t`, "\t")))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	cidrs := table.ASNCIDRs(13335)
+	if len(cidrs) != 1 || cidrs[0].String() != "1.1.1.0/24" {
+		t.Fatalf("ASNCIDRs() = %v, want [1.1.1.0/24]", cidrs)
+	}
+}
@@ -0,0 +1,108 @@
+package range2cidr
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// Iterator 惰性遍历一组CIDR覆盖的所有地址，不在内存中展开完整地址列表
+type Iterator struct {
+	intervals []*cidrInterval
+	idx       int      // 当前所在的区间下标
+	cur       *big.Int // 下一个待返回的地址
+}
+
+// NewIterator 基于CIDR列表构造一个Iterator，内部会先合并重叠/相邻的CIDR
+func NewIterator(cidrs []*net.IPNet) *Iterator {
+	intervals := mergeIntervals(toSortedIntervals(cidrs))
+	it := &Iterator{intervals: intervals}
+	if len(intervals) > 0 {
+		it.cur = new(big.Int).Set(intervals[0].start)
+	}
+	return it
+}
+
+// Seek 将游标移动到指定IP，供调用方从断点恢复遍历；若该IP不在任何区间内，
+// 游标会停在其后第一个仍属于区间的地址上
+func (it *Iterator) Seek(ip net.IP) error {
+	stdIP := standardizeIP(ip)
+	if stdIP == nil {
+		return fmt.Errorf("无效的IP地址")
+	}
+	target := new(big.Int).SetBytes(stdIP)
+	byteLen := len(stdIP)
+
+	for i, iv := range it.intervals {
+		if iv.byteLen != byteLen {
+			continue
+		}
+		if target.Cmp(iv.end) > 0 {
+			continue
+		}
+		it.idx = i
+		if target.Cmp(iv.start) < 0 {
+			it.cur = new(big.Int).Set(iv.start)
+		} else {
+			it.cur = target
+		}
+		return nil
+	}
+
+	// 目标在所有区间之后，遍历直接结束
+	it.idx = len(it.intervals)
+	it.cur = nil
+	return nil
+}
+
+// Next 返回下一个地址，遍历结束时返回 (nil, false)
+func (it *Iterator) Next() (net.IP, bool) {
+	for it.idx < len(it.intervals) {
+		iv := it.intervals[it.idx]
+		if it.cur == nil {
+			it.cur = new(big.Int).Set(iv.start)
+		}
+		if it.cur.Cmp(iv.end) > 0 {
+			it.idx++
+			it.cur = nil
+			continue
+		}
+
+		ip := bigIntToIP(it.cur, iv.byteLen)
+		it.cur = new(big.Int).Add(it.cur, big.NewInt(1))
+		return ip, true
+	}
+	return nil, false
+}
+
+// ForEachIP 遍历CIDR列表覆盖的每一个地址，可选从start恢复，fn返回error时立即终止遍历
+func ForEachIP(cidrs []*net.IPNet, start net.IP, fn func(net.IP) error) error {
+	it := NewIterator(cidrs)
+	if start != nil {
+		if err := it.Seek(start); err != nil {
+			return err
+		}
+	}
+
+	for {
+		ip, ok := it.Next()
+		if !ok {
+			return nil
+		}
+		if err := fn(ip); err != nil {
+			return err
+		}
+	}
+}
+
+// CountIPs 统计CIDR列表覆盖的地址总数（先合并去重，避免重叠区间重复计数）
+func CountIPs(cidrs []*net.IPNet) *big.Int {
+	intervals := mergeIntervals(toSortedIntervals(cidrs))
+	total := new(big.Int)
+	for _, iv := range intervals {
+		size := new(big.Int).Sub(iv.end, iv.start)
+		size.Add(size, big.NewInt(1))
+		total.Add(total, size)
+	}
+	return total
+}
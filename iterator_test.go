@@ -0,0 +1,57 @@
+package range2cidr
+
+import (
+	"net"
+	"testing"
+)
+
+func TestForEachIP(t *testing.T) {
+	cidrs := []*net.IPNet{mustParseCIDR(t, "192.168.0.0/30")}
+
+	var got []string
+	err := ForEachIP(cidrs, nil, func(ip net.IP) error {
+		got = append(got, ip.String())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachIP() error = %v", err)
+	}
+
+	want := []string{"192.168.0.0", "192.168.0.1", "192.168.0.2", "192.168.0.3"}
+	if len(got) != len(want) {
+		t.Fatalf("ForEachIP() visited %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ForEachIP() visited %v, want %v", got, want)
+		}
+	}
+}
+
+func TestForEachIPResume(t *testing.T) {
+	cidrs := []*net.IPNet{mustParseCIDR(t, "192.168.0.0/30")}
+
+	var got []string
+	err := ForEachIP(cidrs, net.ParseIP("192.168.0.2"), func(ip net.IP) error {
+		got = append(got, ip.String())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachIP() error = %v", err)
+	}
+
+	want := []string{"192.168.0.2", "192.168.0.3"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("ForEachIP() resumed at %v, want %v", got, want)
+	}
+}
+
+func TestCountIPs(t *testing.T) {
+	cidrs := []*net.IPNet{
+		mustParseCIDR(t, "192.168.0.0/25"),
+		mustParseCIDR(t, "192.168.0.128/25"), // 与上一个相邻，应合并计数
+	}
+	if got := CountIPs(cidrs).Int64(); got != 256 {
+		t.Fatalf("CountIPs() = %d, want 256", got)
+	}
+}
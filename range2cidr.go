@@ -1,10 +1,11 @@
 package range2cidr
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/big"
+	"math/bits"
 	"net"
-	"testing"
 )
 
 // Range2CIDRs 将IP范围转换为CIDR列表
@@ -21,68 +22,97 @@ func Range2CIDRs(startIP, endIP net.IP) ([]*net.IPNet, error) {
 		return nil, fmt.Errorf("IP版本不匹配")
 	}
 
-	// 转换为big.Int
+	if len(startIP) == net.IPv4len {
+		return range2CIDRsV4(startIP, endIP)
+	}
+	return range2CIDRsV6(startIP, endIP)
+}
+
+// range2CIDRsV4 是IPv4专用的快速路径，全程在uint32寄存器内完成，
+// 避免大范围时per-bit的big.Int循环
+func range2CIDRsV4(startIP, endIP net.IP) ([]*net.IPNet, error) {
+	start := binary.BigEndian.Uint32(startIP)
+	end := binary.BigEndian.Uint32(endIP)
+	if start > end {
+		return nil, fmt.Errorf("起始IP大于结束IP")
+	}
+
+	var cidrs []*net.IPNet
+	for {
+		// 当前地址能起步的最大块大小由其尾随零位数决定
+		maxSize := bits.TrailingZeros32(start)
+		// 同时不能超过剩余地址数量所能表示的最大2的幂次
+		remain := uint64(end-start) + 1
+		for maxSize > 0 && (uint64(1)<<uint(maxSize)) > remain {
+			maxSize--
+		}
+
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, start)
+		cidrs = append(cidrs, &net.IPNet{IP: ip, Mask: net.CIDRMask(32-maxSize, 32)})
+
+		if maxSize == 32 || start+(1<<uint(maxSize))-1 >= end {
+			break
+		}
+		start += 1 << uint(maxSize)
+	}
+	return cidrs, nil
+}
+
+// range2CIDRsV6 是IPv6路径，借助TrailingZeros64/BitLen避免逐位扫描
+func range2CIDRsV6(startIP, endIP net.IP) ([]*net.IPNet, error) {
 	startInt := new(big.Int).SetBytes(startIP)
 	endInt := new(big.Int).SetBytes(endIP)
-
-	// 验证范围
 	if startInt.Cmp(endInt) > 0 {
 		return nil, fmt.Errorf("起始IP大于结束IP")
 	}
 
-	var cidrs []*net.IPNet
-	maxLen := len(startIP) * 8 // IPv4为32，IPv6为128
+	const maxLen = 128
+	one := big.NewInt(1)
 
+	var cidrs []*net.IPNet
 	for startInt.Cmp(endInt) <= 0 {
-		// 计算当前IP到结束IP的差值
 		diff := new(big.Int).Sub(endInt, startInt)
-		diff.Add(diff, big.NewInt(1))
-
-		// 找到最大的可能掩码
-		maxSize := 0
-		for i := 0; i < maxLen; i++ {
-			blockSize := new(big.Int).Lsh(big.NewInt(1), uint(i))
-			if blockSize.Cmp(diff) > 0 {
-				break
-			}
-			maxSize = i
-		}
+		diff.Add(diff, one)
 
-		// 检查网络对齐
-		for i := maxSize; i >= 0; i-- {
-			mask := new(big.Int).Lsh(big.NewInt(1), uint(i))
-			mask.Sub(mask, big.NewInt(1))
-			networkStart := new(big.Int).And(startInt, new(big.Int).Not(mask))
-
-			if networkStart.Cmp(startInt) == 0 {
-				// 计算这个掩码下的最后一个地址
-				networkEnd := new(big.Int).Or(startInt, mask)
-				if networkEnd.Cmp(endInt) <= 0 {
-					maxSize = i
-					break
-				}
-			}
+		// 最大块大小 = min(起始地址的尾随零位数, floor(log2(剩余地址数)))
+		maxSize := trailingZeros128(startInt)
+		if limit := diff.BitLen() - 1; limit < maxSize {
+			maxSize = limit
 		}
 
-		// 创建CIDR
-		prefixLen := maxLen - maxSize
-		ipBytes := make([]byte, len(startIP))
+		ipBytes := make([]byte, net.IPv6len)
 		startInt.FillBytes(ipBytes)
-
-		cidr := &net.IPNet{
+		cidrs = append(cidrs, &net.IPNet{
 			IP:   net.IP(ipBytes),
-			Mask: net.CIDRMask(prefixLen, maxLen),
-		}
-		cidrs = append(cidrs, cidr)
+			Mask: net.CIDRMask(maxLen-maxSize, maxLen),
+		})
 
-		// 移动到下一个网络
-		increment := new(big.Int).Lsh(big.NewInt(1), uint(maxSize))
+		if maxSize == maxLen {
+			break
+		}
+		increment := new(big.Int).Lsh(one, uint(maxSize))
 		startInt.Add(startInt, increment)
 	}
-
 	return cidrs, nil
 }
 
+// trailingZeros128 返回一个128位非负整数的尾随零位数（对0返回128）
+func trailingZeros128(n *big.Int) int {
+	b := make([]byte, net.IPv6len)
+	n.FillBytes(b)
+	high := binary.BigEndian.Uint64(b[:8])
+	low := binary.BigEndian.Uint64(b[8:])
+
+	if low != 0 {
+		return bits.TrailingZeros64(low)
+	}
+	if high != 0 {
+		return 64 + bits.TrailingZeros64(high)
+	}
+	return 128
+}
+
 // standardizeIP 标准化IP地址为4或16字节
 func standardizeIP(ip net.IP) net.IP {
 	if v4 := ip.To4(); v4 != nil {
@@ -90,35 +120,3 @@ func standardizeIP(ip net.IP) net.IP {
 	}
 	return ip.To16()
 }
-
-func TestRange2Cidr(t *testing.T) {
-	// IPv4测试
-	startIP4 := net.ParseIP("192.168.1.0")
-	endIP4 := net.ParseIP("192.168.2.255")
-
-	cidrs4, err := Range2CIDRs(startIP4, endIP4)
-	if err != nil {
-		fmt.Printf("IPv4转换失败: %v\n", err)
-	} else {
-		fmt.Printf("IPv4范围 %s - %s 转换为以下CIDR:\n", startIP4, endIP4)
-		for _, cidr := range cidrs4 {
-			fmt.Println(cidr)
-		}
-	}
-
-	// IPv6测试
-	startIP6 := net.ParseIP("2400:ee00:101c:6100:0:9d41:e2a9:efcf")
-	endIP6 := net.ParseIP("2400:ee00:101c:6100:0:9d41:e2a9:efd7")
-
-	cidrs6, err := Range2CIDRs(startIP6, endIP6)
-	if err != nil {
-		fmt.Printf("IPv6转换失败: %v\n", err)
-	} else {
-		fmt.Printf("\nIPv6范围 %s - %s 转换为以下CIDR:\n", startIP6, endIP6)
-		for _, cidr := range cidrs6 {
-			fmt.Println(cidr)
-		}
-	}
-}
-
-
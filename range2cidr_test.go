@@ -0,0 +1,74 @@
+package range2cidr
+
+import (
+	"net"
+	"testing"
+)
+
+func TestRange2CIDRsIPv4(t *testing.T) {
+	startIP := net.ParseIP("192.168.1.0")
+	endIP := net.ParseIP("192.168.2.255")
+
+	cidrs, err := Range2CIDRs(startIP, endIP)
+	if err != nil {
+		t.Fatalf("Range2CIDRs() error = %v", err)
+	}
+	got := cidrStrings(cidrs)
+	want := []string{"192.168.1.0/24", "192.168.2.0/24"}
+	if len(got) != len(want) {
+		t.Fatalf("Range2CIDRs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Range2CIDRs() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRange2CIDRsIPv6(t *testing.T) {
+	startIP := net.ParseIP("2400:ee00:101c:6100:0:9d41:e2a9:efcf")
+	endIP := net.ParseIP("2400:ee00:101c:6100:0:9d41:e2a9:efd7")
+
+	cidrs, err := Range2CIDRs(startIP, endIP)
+	if err != nil {
+		t.Fatalf("Range2CIDRs() error = %v", err)
+	}
+	if len(cidrs) == 0 {
+		t.Fatalf("Range2CIDRs() returned no CIDRs")
+	}
+
+	// 用CIDRsToRanges往返校验，确认结果恰好覆盖原始范围
+	back := CIDRsToRanges(cidrs)
+	if len(back) != 1 || back[0].Start.String() != startIP.String() || back[0].End.String() != endIP.String() {
+		t.Fatalf("CIDRsToRanges(Range2CIDRs(...)) = %v, want [%s-%s]", back, startIP, endIP)
+	}
+}
+
+func TestRange2CIDRsInvalidOrder(t *testing.T) {
+	startIP := net.ParseIP("192.168.2.255")
+	endIP := net.ParseIP("192.168.1.0")
+	if _, err := Range2CIDRs(startIP, endIP); err == nil {
+		t.Fatalf("Range2CIDRs() expected error when start > end")
+	}
+}
+
+// BenchmarkRange2CIDRsV4Worst覆盖IPv4最坏情形：完全不对齐的超大范围
+func BenchmarkRange2CIDRsV4Worst(b *testing.B) {
+	startIP := net.ParseIP("0.0.0.1")
+	endIP := net.ParseIP("255.255.255.254")
+	for i := 0; i < b.N; i++ {
+		if _, err := Range2CIDRs(startIP, endIP); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRange2CIDRsV6(b *testing.B) {
+	startIP := net.ParseIP("2400:ee00:101c:6100:0:9d41:e2a9:efcf")
+	endIP := net.ParseIP("2400:ee00:101c:6100:0:9d41:e2a9:efd7")
+	for i := 0; i < b.N; i++ {
+		if _, err := Range2CIDRs(startIP, endIP); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package range2cidr
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// IPRange 表示一个闭区间形式的IP范围 [Start, End]
+type IPRange struct {
+	Start, End net.IP
+}
+
+// CIDRsToRanges 将CIDR列表合并后，分解为最少数量的 [Start, End] 区间
+func CIDRsToRanges(nets []*net.IPNet) []IPRange {
+	intervals := mergeIntervals(toSortedIntervals(nets))
+	ranges := make([]IPRange, 0, len(intervals))
+	for _, iv := range intervals {
+		ranges = append(ranges, IPRange{
+			Start: bigIntToIP(iv.start, iv.byteLen),
+			End:   bigIntToIP(iv.end, iv.byteLen),
+		})
+	}
+	return ranges
+}
+
+// ParseRangeLine 解析形如 "1.2.3.4-1.2.3.9" 的范围字符串
+func ParseRangeLine(s string) (IPRange, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), "-", 2)
+	if len(parts) != 2 {
+		return IPRange{}, fmt.Errorf("无效的范围格式: %s", s)
+	}
+
+	start := net.ParseIP(strings.TrimSpace(parts[0]))
+	end := net.ParseIP(strings.TrimSpace(parts[1]))
+	if start == nil || end == nil {
+		return IPRange{}, fmt.Errorf("无效的IP地址: %s", s)
+	}
+	return IPRange{Start: start, End: end}, nil
+}
+
+// ParseDashedOrCIDR 解析 "a.b.c.d/n"、"a-b" 或单个IP（视为 /32 或 /128）三种格式
+func ParseDashedOrCIDR(s string) (IPRange, error) {
+	s = strings.TrimSpace(s)
+
+	switch {
+	case strings.Contains(s, "/"):
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return IPRange{}, fmt.Errorf("无效的CIDR: %s", s)
+		}
+		iv, ok := cidrToInterval(n)
+		if !ok {
+			return IPRange{}, fmt.Errorf("无效的CIDR: %s", s)
+		}
+		return IPRange{
+			Start: bigIntToIP(iv.start, iv.byteLen),
+			End:   bigIntToIP(iv.end, iv.byteLen),
+		}, nil
+
+	case strings.Contains(s, "-"):
+		return ParseRangeLine(s)
+
+	default:
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return IPRange{}, fmt.Errorf("无效的IP地址: %s", s)
+		}
+		return IPRange{Start: ip, End: ip}, nil
+	}
+}
+
+// WriteP2PRanges 按P2P封锁列表惯用的 "start - end" 格式写出范围列表
+func WriteP2PRanges(w io.Writer, ranges []IPRange) error {
+	for _, r := range ranges {
+		if _, err := fmt.Fprintf(w, "%s - %s\n", r.Start, r.End); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteNmapRanges 按nmap -iL可识别的 "start-end" 格式写出范围列表
+func WriteNmapRanges(w io.Writer, ranges []IPRange) error {
+	for _, r := range ranges {
+		if _, err := fmt.Fprintf(w, "%s-%s\n", r.Start, r.End); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadRangesFile 读取范围列表文件，每行一个 ParseDashedOrCIDR 支持的条目，
+// 忽略空行和以 # 或 // 开头的注释行
+func ReadRangesFile(r io.Reader) ([]IPRange, error) {
+	var ranges []IPRange
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		rg, err := ParseDashedOrCIDR(line)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, rg)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
@@ -0,0 +1,69 @@
+package range2cidr
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestCIDRsToRanges(t *testing.T) {
+	cidrs := []*net.IPNet{
+		mustParseCIDR(t, "192.168.0.0/25"),
+		mustParseCIDR(t, "192.168.0.128/25"),
+	}
+	ranges := CIDRsToRanges(cidrs)
+	if len(ranges) != 1 {
+		t.Fatalf("CIDRsToRanges() returned %d ranges, want 1", len(ranges))
+	}
+	if ranges[0].Start.String() != "192.168.0.0" || ranges[0].End.String() != "192.168.0.255" {
+		t.Fatalf("CIDRsToRanges() = %v", ranges[0])
+	}
+}
+
+func TestParseDashedOrCIDR(t *testing.T) {
+	cases := []struct {
+		in         string
+		start, end string
+	}{
+		{"192.168.0.0/30", "192.168.0.0", "192.168.0.3"},
+		{"1.2.3.4-1.2.3.9", "1.2.3.4", "1.2.3.9"},
+		{"8.8.8.8", "8.8.8.8", "8.8.8.8"},
+	}
+
+	for _, c := range cases {
+		rg, err := ParseDashedOrCIDR(c.in)
+		if err != nil {
+			t.Fatalf("ParseDashedOrCIDR(%q) error = %v", c.in, err)
+		}
+		if rg.Start.String() != c.start || rg.End.String() != c.end {
+			t.Fatalf("ParseDashedOrCIDR(%q) = %v-%v, want %v-%v", c.in, rg.Start, rg.End, c.start, c.end)
+		}
+	}
+}
+
+func TestReadRangesFile(t *testing.T) {
+	input := strings.NewReader(`
+# 注释行
+192.168.0.0/30
+
+1.2.3.4-1.2.3.5
+`)
+	ranges, err := ReadRangesFile(input)
+	if err != nil {
+		t.Fatalf("ReadRangesFile() error = %v", err)
+	}
+	if len(ranges) != 2 {
+		t.Fatalf("ReadRangesFile() returned %d ranges, want 2", len(ranges))
+	}
+}
+
+func TestWriteNmapRanges(t *testing.T) {
+	var buf strings.Builder
+	ranges := []IPRange{{Start: net.ParseIP("1.2.3.4"), End: net.ParseIP("1.2.3.9")}}
+	if err := WriteNmapRanges(&buf, ranges); err != nil {
+		t.Fatalf("WriteNmapRanges() error = %v", err)
+	}
+	if buf.String() != "1.2.3.4-1.2.3.9\n" {
+		t.Fatalf("WriteNmapRanges() = %q", buf.String())
+	}
+}
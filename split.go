@@ -0,0 +1,89 @@
+package range2cidr
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"net"
+)
+
+// 子网拆分方式
+const (
+	MethodSubnetNum = iota // 按目标子网数量拆分
+	MethodHostNum          // 按目标主机数量拆分
+)
+
+// smallestHostBits 返回满足 2^hostBits >= required 的最小hostBits
+func smallestHostBits(required *big.Int) int {
+	hostBits := 0
+	cap := big.NewInt(1)
+	for cap.Cmp(required) < 0 {
+		cap.Lsh(cap, 1)
+		hostBits++
+	}
+	return hostBits
+}
+
+// SplitCIDR 将父网n按method指定的方式拆分子网：MethodSubnetNum时num为子网数量，
+// 父网被等分为num个子网；MethodHostNum时num为每个子网需容纳的主机数，
+// 返回父网内按所需前缀长度划分出的全部子网（数量不一定等于num）
+func SplitCIDR(n *net.IPNet, method int, num int) ([]*net.IPNet, error) {
+	if n == nil {
+		return nil, fmt.Errorf("无效的CIDR")
+	}
+	ip := standardizeIP(n.IP)
+	if ip == nil {
+		return nil, fmt.Errorf("无效的CIDR")
+	}
+	byteLen := len(ip)
+	addrBits := byteLen * 8
+	ones, maskBits := n.Mask.Size()
+	if maskBits != addrBits {
+		return nil, fmt.Errorf("无效的CIDR")
+	}
+
+	var newPrefix int
+	switch method {
+	case MethodSubnetNum:
+		if num <= 0 || num&(num-1) != 0 {
+			return nil, fmt.Errorf("子网数量必须是2的幂: %d", num)
+		}
+		extra := bits.TrailingZeros(uint(num))
+		newPrefix = ones + extra
+		if newPrefix > addrBits {
+			return nil, fmt.Errorf("请求的子网数量超出父网容量: %d", num)
+		}
+
+	case MethodHostNum:
+		if num <= 0 {
+			return nil, fmt.Errorf("主机数量必须大于0")
+		}
+		newPrefix = addrBits - smallestHostBits(big.NewInt(int64(num)))
+		if addrBits == 32 && newPrefix < 31 {
+			// /31、/32不保留网络/广播地址；其余情况需要为它们预留+2的余量重算
+			required := new(big.Int).Add(big.NewInt(int64(num)), big.NewInt(2))
+			newPrefix = addrBits - smallestHostBits(required)
+		}
+		if newPrefix < ones {
+			return nil, fmt.Errorf("请求的主机数量超出父网容量: %d", num)
+		}
+
+	default:
+		return nil, fmt.Errorf("未知的拆分方式: %d", method)
+	}
+
+	start := new(big.Int).SetBytes(ip)
+	blockSize := new(big.Int).Lsh(big.NewInt(1), uint(addrBits-newPrefix))
+	childCount := new(big.Int).Lsh(big.NewInt(1), uint(newPrefix-ones))
+
+	result := make([]*net.IPNet, 0)
+	cur := new(big.Int).Set(start)
+	for i := new(big.Int); i.Cmp(childCount) < 0; i.Add(i, big.NewInt(1)) {
+		result = append(result, &net.IPNet{
+			IP:   bigIntToIP(cur, byteLen),
+			Mask: net.CIDRMask(newPrefix, addrBits),
+		})
+		cur.Add(cur, blockSize)
+	}
+	return result, nil
+}
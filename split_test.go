@@ -0,0 +1,91 @@
+package range2cidr
+
+import (
+	"net"
+	"testing"
+)
+
+func TestSplitCIDRBySubnetNum(t *testing.T) {
+	parent := mustParseCIDR(t, "192.168.0.0/24")
+	got, err := SplitCIDR(parent, MethodSubnetNum, 4)
+	if err != nil {
+		t.Fatalf("SplitCIDR() error = %v", err)
+	}
+	want := []string{"192.168.0.0/26", "192.168.0.64/26", "192.168.0.128/26", "192.168.0.192/26"}
+	if len(got) != len(want) {
+		t.Fatalf("SplitCIDR() = %v, want %v", cidrStrings(got), want)
+	}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Fatalf("SplitCIDR() = %v, want %v", cidrStrings(got), want)
+		}
+	}
+}
+
+func TestSplitCIDRByHostNum(t *testing.T) {
+	parent := mustParseCIDR(t, "192.168.0.0/24")
+	got, err := SplitCIDR(parent, MethodHostNum, 50)
+	if err != nil {
+		t.Fatalf("SplitCIDR() error = %v", err)
+	}
+	// 50台主机 + 网络/广播地址 => 需要 /26 (62个可用主机)
+	for _, n := range got {
+		ones, _ := n.Mask.Size()
+		if ones != 26 {
+			t.Fatalf("SplitCIDR() prefix = %d, want 26", ones)
+		}
+	}
+	if len(got) != 4 {
+		t.Fatalf("SplitCIDR() returned %d subnets, want 4", len(got))
+	}
+}
+
+func TestSplitCIDRByHostNumPointToPoint(t *testing.T) {
+	parent := mustParseCIDR(t, "192.168.0.0/24")
+
+	// /32、/31不保留网络/广播地址，不应套用+2的余量
+	cases := []struct {
+		num        int
+		wantPrefix int
+	}{
+		{1, 32},
+		{2, 31},
+	}
+	for _, c := range cases {
+		got, err := SplitCIDR(parent, MethodHostNum, c.num)
+		if err != nil {
+			t.Fatalf("SplitCIDR(num=%d) error = %v", c.num, err)
+		}
+		if ones, _ := got[0].Mask.Size(); ones != c.wantPrefix {
+			t.Fatalf("SplitCIDR(num=%d) prefix = %d, want %d", c.num, ones, c.wantPrefix)
+		}
+	}
+}
+
+func TestSplitCIDRExceedsCapacity(t *testing.T) {
+	parent := mustParseCIDR(t, "192.168.0.0/24")
+	if _, err := SplitCIDR(parent, MethodSubnetNum, 1024); err == nil {
+		t.Fatalf("SplitCIDR() expected error when exceeding parent capacity")
+	}
+}
+
+func TestSplitCIDRInvalidSubnetNum(t *testing.T) {
+	parent := mustParseCIDR(t, "192.168.0.0/24")
+	if _, err := SplitCIDR(parent, MethodSubnetNum, 3); err == nil {
+		t.Fatalf("SplitCIDR() expected error for non-power-of-two subnet count")
+	}
+}
+
+func TestSplitCIDRIPv6(t *testing.T) {
+	_, parent, err := net.ParseCIDR("2400:ee00:101c:6100::/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+	got, err := SplitCIDR(parent, MethodSubnetNum, 2)
+	if err != nil {
+		t.Fatalf("SplitCIDR() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("SplitCIDR() returned %d subnets, want 2", len(got))
+	}
+}